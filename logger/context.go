@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ctxKeyType 避免和调用方自定义的context key冲突的内部类型
+type ctxKeyType struct{ name string }
+
+// contextKeys 记录自动从context.Context中提取并写入日志字段的映射关系：
+// 日志字段名 -> context.Context中取值使用的key
+var contextKeys = map[string]any{
+	"trace_id":   ctxKeyType{"trace_id"},
+	"span_id":    ctxKeyType{"span_id"},
+	"request_id": ctxKeyType{"request_id"},
+	"user_id":    ctxKeyType{"user_id"},
+}
+
+// RegisterContextKey 注册一个需要自动提取的字段，name是写入日志时使用的字段名，
+// ctxKey是context.WithValue存取时使用的key
+func RegisterContextKey(name string, ctxKey any) {
+	contextKeys[name] = ctxKey
+}
+
+// CtxWithTraceID 将trace_id写入context.Context，便于WithContext/LogWithCtx自动提取
+func CtxWithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeys["trace_id"], id)
+}
+
+// CtxWithSpanID 将span_id写入context.Context
+func CtxWithSpanID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeys["span_id"], id)
+}
+
+// CtxWithRequestID 将request_id写入context.Context
+func CtxWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeys["request_id"], id)
+}
+
+// CtxWithUserID 将user_id写入context.Context
+func CtxWithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKeys["user_id"], id)
+}
+
+// ctxFields 从context.Context中提取所有已注册且存在的字段
+func ctxFields(ctx context.Context) []zap.Field {
+	fields := make([]zap.Field, 0, len(contextKeys))
+	for name, key := range contextKeys {
+		if v := ctx.Value(key); v != nil {
+			fields = append(fields, zap.Any(name, v))
+		}
+	}
+
+	return fields
+}
+
+// WithContext 从context.Context中提取已注册的字段，返回携带这些字段的zap.Logger，
+// 这样跨服务调用时无需手动把trace_id等信息透传进每一处options map
+func WithContext(ctx context.Context) *zap.Logger {
+	if fLogger == nil {
+		InitLogger()
+	}
+
+	fields := ctxFields(ctx)
+	if len(fields) == 0 {
+		return fLogger
+	}
+
+	return fLogger.With(fields...)
+}
+
+// LogWithCtx 记录日志的同时自动附带context.Context中已注册的字段
+func LogWithCtx(ctx context.Context, level string, msg string, options map[string]interface{}) {
+	if fLogger == nil {
+		InitLogger()
+	}
+
+	fields := append(ctxFields(ctx), parseFields(options)...)
+
+	ce := fLogger.Check(getLevel(level), msg)
+	if ce == nil {
+		return
+	}
+	ce.Write(fields...)
+}
@@ -0,0 +1,49 @@
+package logger
+
+import "go.uber.org/zap/zapcore"
+
+// EncoderFactory 根据EncoderConfig构建zapcore.Encoder，用于RegisterEncoder扩展第三方编码格式
+type EncoderFactory func(zapcore.EncoderConfig) zapcore.Encoder
+
+// encoderKind 当前生效的编码格式名称，默认"json"
+var encoderKind = "json"
+
+// encoderConfigFunc 允许调用方在initCore构建默认EncoderConfig之后做进一步自定义，
+// 比如重命名字段、更换时间格式
+var encoderConfigFunc func(*zapcore.EncoderConfig)
+
+// encoderRegistry 内置及通过RegisterEncoder注册的编码器工厂
+var encoderRegistry = map[string]EncoderFactory{
+	"json":    func(c zapcore.EncoderConfig) zapcore.Encoder { return zapcore.NewJSONEncoder(c) },
+	"console": func(c zapcore.EncoderConfig) zapcore.Encoder { return zapcore.NewConsoleEncoder(c) },
+}
+
+// SetEncoder 设置日志编码格式，内置支持"json"和"console"，其余名称需先通过RegisterEncoder注册
+func SetEncoder(kind string) {
+	encoderKind = kind
+}
+
+// SetEncoderConfig 自定义EncoderConfig，比如重命名字段、更换时间格式(RFC3339Nano、epoch毫秒等)
+func SetEncoderConfig(fn func(*zapcore.EncoderConfig)) {
+	encoderConfigFunc = fn
+}
+
+// RegisterEncoder 注册自定义编码器，比如logfmt等第三方格式，无需fork本包即可接入
+func RegisterEncoder(name string, factory EncoderFactory) {
+	encoderRegistry[name] = factory
+}
+
+// buildEncoder 根据当前encoderKind和自定义EncoderConfig构建Encoder，
+// 找不到对应的工厂时回退到json编码器
+func buildEncoder(conf zapcore.EncoderConfig) zapcore.Encoder {
+	if encoderConfigFunc != nil {
+		encoderConfigFunc(&conf)
+	}
+
+	factory, ok := encoderRegistry[encoderKind]
+	if !ok {
+		factory = encoderRegistry["json"]
+	}
+
+	return factory(conf)
+}
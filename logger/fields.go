@@ -0,0 +1,73 @@
+package logger
+
+import "go.uber.org/zap"
+
+// Logger 对zap.Logger的轻量封装，提供链式With和typed字段API，
+// 作为map[string]interface{}方式的替代，避免热路径上的反射和额外分配
+type Logger struct {
+	z *zap.Logger
+}
+
+// L 返回基于当前全局fLogger的Logger封装，fLogger未初始化时会先调用InitLogger
+func L() *Logger {
+	if fLogger == nil {
+		InitLogger()
+	}
+
+	return &Logger{z: fLogger}
+}
+
+// With 返回携带额外字段的子Logger，不产生map分配和zap.Any的反射开销
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{z: l.z.With(fields...)}
+}
+
+// Debug debug级别日志
+func (l *Logger) Debug(msg string, fields ...zap.Field) {
+	l.z.Debug(msg, fields...)
+}
+
+// Info info级别日志
+func (l *Logger) Info(msg string, fields ...zap.Field) {
+	l.z.Info(msg, fields...)
+}
+
+// Warn 警告类型的日志
+func (l *Logger) Warn(msg string, fields ...zap.Field) {
+	l.z.Warn(msg, fields...)
+}
+
+// Error 错误类型的日志
+func (l *Logger) Error(msg string, fields ...zap.Field) {
+	l.z.Error(msg, fields...)
+}
+
+// DPanic 调试模式下的panic，程序不退出，继续运行
+func (l *Logger) DPanic(msg string, fields ...zap.Field) {
+	l.z.DPanic(msg, fields...)
+}
+
+// Panic 记录日志后panic，退出当前goroutine
+func (l *Logger) Panic(msg string, fields ...zap.Field) {
+	l.z.Panic(msg, fields...)
+}
+
+// Fatal 记录日志后退出程序
+func (l *Logger) Fatal(msg string, fields ...zap.Field) {
+	l.z.Fatal(msg, fields...)
+}
+
+// 下面是zap.Field构造函数的重导出，方便调用方直接使用logger.String、logger.Int等，
+// 无需额外import zap，热路径可以写成logger.L().With(logger.String("route", "/x")).Info("hit")
+// 注意Err对应zap.Error，这里不能直接叫Error，会和包里已有的map版本Error函数冲突
+var (
+	String   = zap.String
+	Int      = zap.Int
+	Int64    = zap.Int64
+	Float64  = zap.Float64
+	Bool     = zap.Bool
+	Duration = zap.Duration
+	Err      = zap.Error
+	Any      = zap.Any
+	Time     = zap.Time
+)
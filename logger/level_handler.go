@@ -0,0 +1,16 @@
+package logger
+
+import "net/http"
+
+// LevelHandler 返回一个http.Handler，GET读取当前日志级别，PUT/POST修改日志级别，
+// 语义与zap.AtomicLevel内置的ServeHTTP保持一致（POST按PUT处理），可以直接挂载到服务的调试路由上，
+// 这样无需重启进程即可把运行中服务从info切到debug排查问题
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			r = r.Clone(r.Context())
+			r.Method = http.MethodPut
+		}
+		atomicLevel.ServeHTTP(w, r)
+	})
+}
@@ -10,7 +10,6 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // logger句柄，支持zap logger上的Debug,Info,Error,Panic,Warn,Fatal等方法
@@ -42,6 +41,10 @@ var (
 	logDir           = ""           // 日志文件存放目录
 )
 
+// atomicLevel 持有实际生效的日志级别，initCore构建core时会传入它作为LevelEnabler，
+// 之后LogLevel只需调用atomicLevel.SetLevel即可实时调整运行中logger的级别，无需重新初始化
+var atomicLevel = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+
 // MaxAge 日志保留时间
 func MaxAge(n int) {
 	logMaxAge = n
@@ -62,9 +65,11 @@ func TraceFileLine(b bool) {
 	logTraceFileLine = b
 }
 
-// LogLevel 日志级别
+// LogLevel 设置日志级别，如果core已经初始化，会同时更新atomicLevel，
+// 使运行中的logger立即生效，无需重启进程
 func LogLevel(lvl string) {
 	logLevel = lvl
+	atomicLevel.SetLevel(getLevel(lvl))
 }
 
 // SetLogFile 设置日志文件路径，如果日志文件不存在zap会自动创建文件
@@ -124,14 +129,8 @@ func initCore() {
 	}
 
 	// 日志最低级别设置
-	level := getLevel(logLevel)
-	syncWriter := zapcore.AddSync(&lumberjack.Logger{
-		Filename:  logFileName, // ⽇志⽂件路径
-		MaxSize:   logMaxSize,  // 单位为MB,默认为512MB
-		MaxAge:    logMaxAge,   // 文件最多保存多少天
-		LocalTime: true,        // 采用本地时间
-		Compress:  logCompress, // 是否压缩日志
-	})
+	atomicLevel.SetLevel(getLevel(logLevel))
+	syncWriter := newSyncWriter(logFileName, defaultRollConfig())
 
 	encoderConf := zapcore.EncoderConfig{
 		TimeKey:        "time_local", // 本地时间
@@ -146,7 +145,17 @@ func initCore() {
 		EncodeName:     zapcore.FullNameEncoder,
 	}
 
-	core = zapcore.NewCore(zapcore.NewJSONEncoder(encoderConf), syncWriter, zap.NewAtomicLevelAt(level))
+	if len(levelSinks) > 0 {
+		core = buildTeeCore(encoderConf, syncWriter)
+	} else {
+		core = zapcore.NewCore(buildEncoder(encoderConf), syncWriter, atomicLevel)
+
+		if mirrorToStderr {
+			core = zapcore.NewTee(core, consoleCore(encoderConf))
+		}
+	}
+
+	core = wrapRateLimit(wrapSampling(core))
 }
 
 /**
@@ -195,6 +204,10 @@ func LogSugar(skip ...int) *zap.SugaredLogger {
 	return logger.Sugar()
 }
 
+// Debug/Info/Warn/Error/DPanic/Panic/Fatal 是基于map[string]interface{}的兼容层，
+// 每次调用都会产生map分配和zap.Any的反射开销；性能敏感的热路径请改用L().With(...)配合
+// logger.String/logger.Int等typed字段构造函数
+
 // Debug debug日志直接输出到终端
 func Debug(msg string, options map[string]interface{}) {
 	log.Println("msg: ", msg)
@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RotationType 日志滚动方式
+type RotationType string
+
+const (
+	RotationSingle RotationType = "single" // 不按时间切割，仅依赖lumberjack按大小切割
+	RotationDaily  RotationType = "daily"  // 每天本地零点切换到新文件
+	RotationHourly RotationType = "hourly" // 每小时整点切换到新文件
+)
+
+// rotationType 当前生效的按时间滚动模式，默认不按时间切割
+var rotationType = RotationSingle
+
+// SetRotationType 设置按时间滚动的模式，支持"single"、"daily"、"hourly"，非法值按"single"处理
+func SetRotationType(t string) {
+	switch RotationType(t) {
+	case RotationDaily, RotationHourly:
+		rotationType = RotationType(t)
+	default:
+		rotationType = RotationSingle
+	}
+}
+
+// rollConfig 描述按大小切割单个文件时使用的lumberjack参数，独立sink和默认文件
+// 各自的MaxSize/MaxAge/MaxBackups/Compress不同，因此单独抽出来传递，而不是都依赖全局变量
+type rollConfig struct {
+	MaxSize    int
+	MaxAge     int
+	MaxBackups int
+	Compress   bool
+}
+
+// defaultRollConfig 默认文件使用的切割参数，取自包级别的MaxSize/MaxAge/Compress配置
+func defaultRollConfig() rollConfig {
+	return rollConfig{MaxSize: logMaxSize, MaxAge: logMaxAge, Compress: logCompress}
+}
+
+// newSyncWriter 根据当前rotationType构建对应的zapcore.WriteSyncer
+// single模式下直接使用lumberjack做按大小切割；daily/hourly模式下额外包一层按时间切割的writer，
+// 切割后的文件在当前周期内仍然交由lumberjack做二次的大小切割
+func newSyncWriter(fileName string, cfg rollConfig) zapcore.WriteSyncer {
+	if rotationType == RotationSingle {
+		return zapcore.AddSync(&lumberjack.Logger{
+			Filename:   fileName,
+			MaxSize:    cfg.MaxSize,
+			MaxAge:     cfg.MaxAge,
+			MaxBackups: cfg.MaxBackups,
+			LocalTime:  true,
+			Compress:   cfg.Compress,
+		})
+	}
+
+	return newTimeRotateWriter(fileName, rotationType, cfg)
+}
+
+// timeRotateWriter 按天/按小时切割日志文件的WriteSyncer
+// 每次Write时检查当前时间所在的周期标识是否变化，变化则在锁保护下切换到新文件，
+// 并异步清理超过cfg.MaxAge天的历史文件
+type timeRotateWriter struct {
+	mu       sync.Mutex
+	dir      string
+	baseName string // 不含日期后缀和扩展名的文件名，如go-zap.log对应go-zap
+	ext      string // 文件扩展名，如.log
+	rotation RotationType
+	cfg      rollConfig // 周期内按大小二次切割时使用的lumberjack参数
+	current  string     // 当前周期token，如2024-01-15或2024-01-15-08
+	writer   *lumberjack.Logger
+}
+
+func newTimeRotateWriter(fileName string, rotation RotationType, cfg rollConfig) *timeRotateWriter {
+	dir := filepath.Dir(fileName)
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(filepath.Base(fileName), ext)
+
+	w := &timeRotateWriter{
+		dir:      dir,
+		baseName: base,
+		ext:      ext,
+		rotation: rotation,
+		cfg:      cfg,
+	}
+	w.rotateLocked(time.Now())
+
+	return w
+}
+
+// periodToken 返回给定时间对应的周期标识
+func (w *timeRotateWriter) periodToken(t time.Time) string {
+	if w.rotation == RotationHourly {
+		return t.Format("2006-01-02-15")
+	}
+
+	return t.Format("2006-01-02")
+}
+
+func (w *timeRotateWriter) Write(p []byte) (int, error) {
+	now := time.Now()
+	token := w.periodToken(now)
+
+	w.mu.Lock()
+	if token != w.current {
+		w.rotateLocked(now)
+	}
+	writer := w.writer
+	w.mu.Unlock()
+
+	return writer.Write(p)
+}
+
+// Sync 按时间切割的writer本身不缓冲数据，底层lumberjack.Logger每次Write都直接写入文件，
+// 这里不做额外的落盘操作，直接返回nil
+func (w *timeRotateWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return nil
+}
+
+// rotateLocked 切换到token对应的新文件，调用方需持有w.mu
+func (w *timeRotateWriter) rotateLocked(now time.Time) {
+	token := w.periodToken(now)
+	fileName := filepath.Join(w.dir, fmt.Sprintf("%s-%s%s", w.baseName, token, w.ext))
+
+	if w.writer != nil {
+		_ = w.writer.Close()
+	}
+
+	w.writer = &lumberjack.Logger{
+		Filename:   fileName,
+		MaxSize:    w.cfg.MaxSize,
+		MaxAge:     w.cfg.MaxAge,
+		MaxBackups: w.cfg.MaxBackups,
+		LocalTime:  true,
+		Compress:   w.cfg.Compress,
+	}
+	w.current = token
+
+	go w.cleanupOld()
+}
+
+// cleanupOld 异步清理超过cfg.MaxAge天的历史日志文件。
+// 和lumberjack的约定保持一致：MaxAge<=0表示不按时间清理，否则0会被当成
+// "立刻清空"，把刚切换过去、刚写入的文件也删掉
+func (w *timeRotateWriter) cleanupOld() {
+	if w.cfg.MaxAge <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -w.cfg.MaxAge)
+	prefix := w.baseName + "-"
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if info.ModTime().Before(cutoff) {
+			_ = os.Remove(filepath.Join(w.dir, entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCleanupOldSkipsWhenMaxAgeZero 验证MaxAge为Go零值时按lumberjack的约定
+// 不清理任何历史文件，而不是把cutoff收敛成"现在"导致误删刚写入的文件
+func TestCleanupOldSkipsWhenMaxAgeZero(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "old-2020-01-01.log")
+	if err := os.WriteFile(fileName, []byte("x"), 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(fileName, old, old); err != nil {
+		t.Fatalf("修改文件时间失败: %v", err)
+	}
+
+	w := &timeRotateWriter{dir: dir, baseName: "old", ext: ".log", cfg: rollConfig{MaxAge: 0}}
+	w.cleanupOld()
+
+	if _, err := os.Stat(fileName); err != nil {
+		t.Fatalf("MaxAge为0时不应该清理历史文件: %v", err)
+	}
+}
+
+// TestCleanupOldRemovesExpiredFiles 验证配置了正常的MaxAge时，超期文件依然会被清理
+func TestCleanupOldRemovesExpiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "old-2020-01-01.log")
+	if err := os.WriteFile(fileName, []byte("x"), 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(fileName, old, old); err != nil {
+		t.Fatalf("修改文件时间失败: %v", err)
+	}
+
+	w := &timeRotateWriter{dir: dir, baseName: "old", ext: ".log", cfg: rollConfig{MaxAge: 1}}
+	w.cleanupOld()
+
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		t.Fatalf("超过MaxAge的历史文件应该被清理")
+	}
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	samplingEnabled    bool          // 是否启用采样
+	samplingTick       = time.Second // 采样窗口
+	samplingFirst      = 100         // 窗口内前N条完整记录
+	samplingThereafter = 100         // 超过first之后，每thereafter条记录1条
+)
+
+// EnableSampling 是否启用基于zap内置采样器的日志限流
+func EnableSampling(b bool) {
+	samplingEnabled = b
+}
+
+// SetSampling 配置采样窗口、窗口内完整记录的条数，以及超出后每隔多少条采样1条
+func SetSampling(tick time.Duration, first, thereafter int) {
+	samplingTick = tick
+	samplingFirst = first
+	samplingThereafter = thereafter
+}
+
+// wrapSampling 采样开启时用zapcore.NewSamplerWithOptions包裹core，避免突发日志量打满IO
+func wrapSampling(core zapcore.Core) zapcore.Core {
+	if !samplingEnabled {
+		return core
+	}
+
+	return zapcore.NewSamplerWithOptions(core, samplingTick, samplingFirst, samplingThereafter)
+}
+
+var (
+	rateLimitEnabled bool // 是否启用按(level,message)维度的限流去重
+	rateLimitPerSec  = 10 // 每个(level,message)组合每秒最多放行的条数
+)
+
+// dedupeWindow 去重统计使用的时间窗口，与生产环境常见的日志风暴场景对齐
+const dedupeWindow = time.Second
+
+// EnableRateLimit 是否启用按(level,message)维度的限流去重
+func EnableRateLimit(b bool) {
+	rateLimitEnabled = b
+}
+
+// SetRateLimit 设置每个(level,message)组合每秒最多允许通过的条数
+func SetRateLimit(perSecond int) {
+	rateLimitPerSec = perSecond
+}
+
+// wrapRateLimit 限流开启时用rateLimitedCore包裹core
+func wrapRateLimit(core zapcore.Core) zapcore.Core {
+	if !rateLimitEnabled {
+		return core
+	}
+
+	return newRateLimitedCore(core, rateLimitPerSec)
+}
+
+// dedupeEntry 记录某个(level,message)在当前窗口内的放行情况
+type dedupeEntry struct {
+	windowStart time.Time
+	count       int
+}
+
+// rateLimitedCore 按(level,message)去重，避免相同日志在短时间内刷屏。
+// 窗口内超出limit的条目被直接丢弃，下一个放行窗口开始时，第一条日志会携带
+// dropped字段说明上一个窗口丢弃了多少条，方便事后排查日志风暴期间丢了多少信息
+type rateLimitedCore struct {
+	zapcore.Core
+	mu      *sync.Mutex
+	limit   int
+	entries map[string]*dedupeEntry
+}
+
+func newRateLimitedCore(core zapcore.Core, limit int) zapcore.Core {
+	return &rateLimitedCore{
+		Core:    core,
+		mu:      &sync.Mutex{},
+		limit:   limit,
+		entries: make(map[string]*dedupeEntry),
+	}
+}
+
+// Check 必须把决策权交给被包裹的core（例如wrapSampling包裹的sampler），
+// 否则直接用c.Enabled会跳过sampler.Check里真正做first/thereafter计数的逻辑，
+// 导致采样和限流叠加使用时采样完全失效。这里用一次试探性调用（传入nil）让内层
+// core做出真实决策（计数的副作用只发生这一次），只有被内层接受时才把自己
+// 加入ce，这样Write时才能叠加限流的去重统计
+func (c *rateLimitedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Check(ent, nil) == nil {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+func (c *rateLimitedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := ent.Level.String() + "|" + ent.Message
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok || ent.Time.Sub(e.windowStart) >= dedupeWindow {
+		dropped := 0
+		if ok && e.count > c.limit {
+			dropped = e.count - c.limit
+		}
+		c.entries[key] = &dedupeEntry{windowStart: ent.Time, count: 1}
+		c.mu.Unlock()
+
+		if dropped > 0 {
+			fields = append(fields, zapcore.Field{Key: "dropped", Type: zapcore.Int64Type, Integer: int64(dropped)})
+		}
+		return c.Core.Write(ent, fields)
+	}
+
+	e.count++
+	allow := e.count <= c.limit
+	c.mu.Unlock()
+
+	if !allow {
+		return nil
+	}
+
+	return c.Core.Write(ent, fields)
+}
+
+func (c *rateLimitedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitedCore{
+		Core:    c.Core.With(fields),
+		mu:      c.mu,
+		limit:   c.limit,
+		entries: c.entries,
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestRateLimitedCorePreservesSamplerDecision 验证rateLimitedCore包裹sampler时，
+// sampler真正的first/thereafter计数逻辑依然生效，而不是被rateLimitedCore的
+// Check短路掉
+func TestRateLimitedCorePreservesSamplerDecision(t *testing.T) {
+	obsCore, logs := observer.New(zapcore.InfoLevel)
+
+	sampled := zapcore.NewSamplerWithOptions(obsCore, time.Minute, 2, 0)
+	limited := newRateLimitedCore(sampled, 1000) // 限流阈值足够大，避免它本身成为瓶颈
+
+	l := zap.New(limited)
+	for i := 0; i < 20; i++ {
+		l.Info("repeated message")
+	}
+
+	if got := logs.Len(); got != 2 {
+		t.Fatalf("采样叠加限流时应该依然只放行2条，got %d", got)
+	}
+}
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SinkConfig 描述某个日志级别对应的独立输出文件及切割策略
+// 配合SetLevelSink使用，可以让不同级别的日志落到各自的文件中
+type SinkConfig struct {
+	Filename   string // 日志文件路径
+	MaxSize    int    // 单个文件大小上限，单位为Mb
+	MaxAge     int    // 文件最多保存天数
+	MaxBackups int    // 最多保留的历史文件个数
+	Compress   bool   // 是否压缩历史文件
+	Exclusive  bool   // true时该sink只接收与自身相同的级别，不再向上收集更高级别的日志，避免重复记录
+}
+
+// levelSinks 按级别名称配置的独立sink，配置后initCore会为每个sink单独构建core
+var levelSinks = make(map[string]SinkConfig)
+
+// mirrorToStderr 是否在落盘的同时额外输出一份可读日志到终端
+var mirrorToStderr bool
+
+// SetLevelSink 为指定级别配置独立的输出文件和切割策略
+func SetLevelSink(level string, cfg SinkConfig) {
+	levelSinks[level] = cfg
+}
+
+// MirrorToStderr 是否在写文件的同时，额外增加一个console格式的core输出到终端
+func MirrorToStderr(b bool) {
+	mirrorToStderr = b
+}
+
+// buildTeeCore 根据levelSinks为每个级别单独构建core，并通过zapcore.NewTee组合起来。
+// defaultWriter是未配置独立sink时使用的基础文件，这里继续保留它作为兜底：任何没有被
+// sink接收的级别都会落到这个文件里（非独占sink同时接收更高级别，这些级别不再落到这里），
+// 避免因为只配置了部分级别的sink而丢日志
+func buildTeeCore(encoderConf zapcore.EncoderConfig, defaultWriter zapcore.WriteSyncer) zapcore.Core {
+	cores := make([]zapcore.Core, 0, len(levelSinks)+2)
+	enablers := make([]zapcore.LevelEnabler, 0, len(levelSinks))
+
+	for lvlName, cfg := range levelSinks {
+		lvl := getLevel(lvlName)
+		syncWriter := newSyncWriter(cfg.Filename, rollConfig{
+			MaxSize:    cfg.MaxSize,
+			MaxAge:     cfg.MaxAge,
+			MaxBackups: cfg.MaxBackups,
+			Compress:   cfg.Compress,
+		})
+
+		enabler := levelEnabler(lvl, cfg.Exclusive)
+		enablers = append(enablers, enabler)
+		cores = append(cores, zapcore.NewCore(buildEncoder(encoderConf), syncWriter, enabler))
+	}
+
+	cores = append(cores, zapcore.NewCore(buildEncoder(encoderConf), defaultWriter, uncoveredLevelEnabler(enablers)))
+
+	if mirrorToStderr {
+		cores = append(cores, consoleCore(encoderConf))
+	}
+
+	return zapcore.NewTee(cores...)
+}
+
+// uncoveredLevelEnabler 返回一个只在没有任何sink认领该级别时才放行的LevelEnabler，
+// 用作默认core的兜底，保证没有配置sink的级别依然有地方落盘
+func uncoveredLevelEnabler(sinkEnablers []zapcore.LevelEnabler) zapcore.LevelEnabler {
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		if !atomicLevel.Enabled(l) {
+			return false
+		}
+
+		for _, e := range sinkEnablers {
+			if e.Enabled(l) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// levelEnabler 根据sink是否独占，返回对应的LevelEnablerFunc。
+// 独占模式下sink只接收与自身相同的级别，非独占模式下接收该级别及以上的日志；
+// 两种模式都要再AND上atomicLevel，这样LogLevel/LevelHandler实时调整的级别
+// 对per-level sink同样生效，而不只是对没有配置sink时的默认core生效
+func levelEnabler(lvl zapcore.Level, exclusive bool) zapcore.LevelEnabler {
+	if exclusive {
+		return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l == lvl && atomicLevel.Enabled(l)
+		})
+	}
+
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= lvl && atomicLevel.Enabled(l)
+	})
+}
+
+// consoleCore 构建一个输出到标准错误的可读日志core，用于MirrorToStderr。
+// MirrorToStderr的目的就是保留一份人类可读的终端输出，所以这里固定使用
+// NewConsoleEncoder，不能像文件sink那样走buildEncoder按encoderKind选择编码器——
+// 否则默认的json编码会和这里设置的CapitalColorLevelEncoder一起，把ANSI颜色码
+// 写进"level"字段里，既不可读又破坏了JSON。SetEncoderConfig做的字段重命名、
+// 时间格式调整仍然要生效，所以单独应用encoderConfigFunc
+func consoleCore(encoderConf zapcore.EncoderConfig) zapcore.Core {
+	consoleConf := encoderConf
+	consoleConf.EncodeLevel = zapcore.CapitalColorLevelEncoder
+
+	if encoderConfigFunc != nil {
+		encoderConfigFunc(&consoleConf)
+	}
+
+	return zapcore.NewCore(zapcore.NewConsoleEncoder(consoleConf), zapcore.AddSync(os.Stderr), atomicLevel)
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// TestUncoveredLevelEnabler 验证只配置了部分级别sink时，剩下的级别依然会被
+// 默认core的LevelEnabler放行，不会因为levelSinks非空就彻底丢失
+func TestUncoveredLevelEnabler(t *testing.T) {
+	prev := atomicLevel.Level()
+	defer atomicLevel.SetLevel(prev)
+	atomicLevel.SetLevel(zapcore.DebugLevel)
+
+	errorOnly := levelEnabler(zapcore.ErrorLevel, true)
+	fallback := uncoveredLevelEnabler([]zapcore.LevelEnabler{errorOnly})
+
+	if fallback.Enabled(zapcore.ErrorLevel) {
+		t.Fatalf("已经配置了error sink，默认core不应该再重复接收error级别")
+	}
+	if !fallback.Enabled(zapcore.InfoLevel) {
+		t.Fatalf("没有配置sink的info级别应该落到默认core，而不是被丢弃")
+	}
+	if !fallback.Enabled(zapcore.WarnLevel) {
+		t.Fatalf("没有配置sink的warn级别应该落到默认core，而不是被丢弃")
+	}
+}
+
+// TestConsoleCoreAlwaysUsesConsoleEncoder 回归测试：consoleCore必须固定使用
+// NewConsoleEncoder，不能跟着encoderKind走到json，否则CapitalColorLevelEncoder
+// 产生的ANSI颜色码会被写进JSON字符串里，终端不可读，日志采集也会解析失败
+func TestConsoleCoreAlwaysUsesConsoleEncoder(t *testing.T) {
+	prevKind := encoderKind
+	defer func() { encoderKind = prevKind }()
+	encoderKind = "json"
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("创建pipe失败: %v", err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = origStderr }()
+
+	encoderConf := zapcore.EncoderConfig{
+		TimeKey:     "time_local",
+		LevelKey:    "level",
+		MessageKey:  "msg",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+		EncodeTime:  zapcore.ISO8601TimeEncoder,
+		LineEnding:  zapcore.DefaultLineEnding,
+	}
+
+	core := consoleCore(encoderConf)
+	if err := core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: "hit"}, nil); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	_ = w.Close()
+
+	out := make([]byte, 256)
+	n, _ := r.Read(out)
+	line := strings.TrimSpace(string(out[:n]))
+
+	if strings.HasPrefix(line, "{") {
+		t.Fatalf("即使encoderKind=json，MirrorToStderr也应该使用console编码而不是json，got: %q", line)
+	}
+}